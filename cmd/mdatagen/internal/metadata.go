@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Metadata is the top level metadata.yaml schema supported by mdatagen.
+type Metadata struct {
+	// Type of the component.
+	Type string `mapstructure:"type"`
+	// Status information for the component.
+	Status *Status `mapstructure:"status"`
+	// ResourceAttributes that can be emitted by the component.
+	ResourceAttributes map[AttributeName]Attribute `mapstructure:"resource_attributes"`
+	// Attributes emitted by the metrics defined in this metadata.yaml.
+	Attributes map[AttributeName]Attribute `mapstructure:"attributes"`
+	// Metrics that can be emitted by the component.
+	Metrics map[MetricName]Metric `mapstructure:"metrics"`
+	// Telemetry that the component emits about itself, independent of the
+	// pipeline data it processes. Generates a TelemetryBuilder alongside the
+	// existing MetricsBuilder.
+	Telemetry Telemetry `mapstructure:"telemetry"`
+}
+
+// Status describes the stability and distribution of a component.
+type Status struct {
+	Class string `mapstructure:"class"`
+}
+
+// AttributeName is the key used to reference an Attribute definition.
+type AttributeName string
+
+// Attribute describes an attribute that metrics/telemetry can be recorded with.
+type Attribute struct {
+	Description string `mapstructure:"description"`
+	Enabled     bool   `mapstructure:"enabled"`
+}
+
+// MetricName is the key used to reference a Metric definition.
+type MetricName string
+
+// Metric describes an emitted pipeline metric.
+type Metric struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	Description string `mapstructure:"description"`
+	Unit        string `mapstructure:"unit"`
+}
+
+// ReadMetadata reads and validates a metadata.yaml file's contents.
+func ReadMetadata(buf []byte) (Metadata, error) {
+	var md Metadata
+	if err := yaml.Unmarshal(buf, &md); err != nil {
+		return md, fmt.Errorf("failed to unmarshal metadata.yaml: %w", err)
+	}
+	if err := md.Telemetry.Validate(); err != nil {
+		return md, fmt.Errorf("invalid telemetry section: %w", err)
+	}
+	return md, nil
+}
@@ -0,0 +1,96 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// scopeName is the instrumentation scope under which this component's
+// internal telemetry instruments are registered.
+const scopeName = "sample"
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// about their own internal operation, as declared in the `telemetry:`
+// section of metadata.yaml.
+type TelemetryBuilder struct {
+	meter          metric.Meter
+	BatchesDropped metric.Int64Counter
+	BatchesSent    metric.Int64Counter
+	QueueSize      metric.Int64ObservableGauge
+	QueueSizeValue *atomic.Int64
+	SendRetries    metric.Int64Histogram
+}
+
+// telemetryBuilderOption applies changes to default builder configuration.
+type telemetryBuilderOption func(*TelemetryBuilder)
+
+// NewTelemetryBuilder provides a struct with methods to update all internal
+// telemetry for a component.
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...telemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{meter: settings.MeterProvider.Meter(scopeName)}
+	if builder.meter == nil {
+		builder.meter = noop.NewMeterProvider().Meter(scopeName)
+	}
+	var err error
+	var errs error
+	builder.BatchesDropped, err = builder.meter.Int64Counter(
+		"batches.dropped",
+		metric.WithDescription("Number of batches dropped due to a full queue."),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
+	builder.BatchesSent, err = builder.meter.Int64Counter(
+		"batches.sent",
+		metric.WithDescription("Number of batches sent."),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
+	builder.QueueSizeValue = &atomic.Int64{}
+	builder.QueueSize, err = builder.meter.Int64ObservableGauge(
+		"queue.size",
+		metric.WithDescription("Current number of items waiting in the send queue."),
+		metric.WithUnit("1"),
+		metric.WithInt64Callback(func(_ context.Context, obs metric.Int64Observer) error {
+			obs.Observe(builder.QueueSizeValue.Load())
+			return nil
+		}),
+	)
+	errs = errors.Join(errs, err)
+	builder.SendRetries, err = builder.meter.Int64Histogram(
+		"send.retries",
+		metric.WithDescription("Duration taken to retry sending a batch."),
+		metric.WithUnit("ms"),
+	)
+	errs = errors.Join(errs, err)
+	for _, op := range options {
+		op(&builder)
+	}
+	return &builder, errs
+}
+
+// AddBatchesDropped records a value for the batches_dropped instrument.
+func (tb *TelemetryBuilder) AddBatchesDropped(ctx context.Context, val int64) {
+	tb.BatchesDropped.Add(ctx, val)
+}
+
+// AddBatchesSent records a value for the batches_sent instrument.
+func (tb *TelemetryBuilder) AddBatchesSent(ctx context.Context, val int64) {
+	tb.BatchesSent.Add(ctx, val)
+}
+
+// SetQueueSize sets the current value observed for the queue_size instrument.
+func (tb *TelemetryBuilder) SetQueueSize(val int64) {
+	tb.QueueSizeValue.Store(val)
+}
+
+// RecordSendRetries records a value for the send_retries instrument.
+func (tb *TelemetryBuilder) RecordSendRetries(ctx context.Context, val int64) {
+	tb.SendRetries.Record(ctx, val)
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import "fmt"
+
+// Telemetry is the `telemetry:` section of metadata.yaml. Unlike `metrics:`,
+// which describes metrics the component emits about the pipeline data it
+// processes, `telemetry:` describes metrics the component emits about its
+// own internal operation (batches sent, items dropped, queue size, ...).
+//
+// It is rendered into a typed TelemetryBuilder, analogous to the
+// MetricsBuilder generated from `metrics:`.
+type Telemetry struct {
+	// Level is read from metadata.yaml but is not used by the generated
+	// TelemetryBuilder: no default is applied, and instruments are recorded
+	// at every telemetry level.
+	Level string `mapstructure:"level"`
+	// Metrics are the self-observability instruments the component records.
+	Metrics map[MetricName]TelemetryMetric `mapstructure:"metrics"`
+}
+
+// InstrumentType is the kind of OpenTelemetry metrics instrument to generate.
+type InstrumentType string
+
+const (
+	InstrumentTypeCounter       InstrumentType = "counter"
+	InstrumentTypeHistogram     InstrumentType = "histogram"
+	InstrumentTypeGauge         InstrumentType = "gauge"
+	InstrumentTypeUpDownCounter InstrumentType = "updowncounter"
+)
+
+func (it InstrumentType) validate() error {
+	switch it {
+	case InstrumentTypeCounter, InstrumentTypeHistogram, InstrumentTypeGauge, InstrumentTypeUpDownCounter, "":
+		return nil
+	default:
+		return fmt.Errorf("unsupported instrument type: %q", it)
+	}
+}
+
+// TelemetryMetric describes a single self-observability instrument.
+type TelemetryMetric struct {
+	Enabled     bool           `mapstructure:"enabled"`
+	Description string         `mapstructure:"description"`
+	Unit        string         `mapstructure:"unit"`
+	Instrument  InstrumentType `mapstructure:"instrument"`
+	// Async marks the instrument as an asynchronous (observable) gauge/counter,
+	// recorded via a callback that observes the value stored by the generated
+	// Set method, rather than an Add/Record call.
+	Async bool `mapstructure:"async"`
+	// Attributes lists attribute names from the top level `attributes:`
+	// section. When it is non-empty, the generated Add/Record method takes a
+	// variadic list of metric options; the names themselves are not used in
+	// the generated code.
+	Attributes []AttributeName `mapstructure:"attributes"`
+}
+
+// Validate checks that the telemetry section is internally consistent.
+func (t Telemetry) Validate() error {
+	for name, metric := range t.Metrics {
+		if err := metric.Instrument.validate(); err != nil {
+			return fmt.Errorf("telemetry metric %q: %w", name, err)
+		}
+		if metric.Async && metric.Instrument == InstrumentTypeHistogram {
+			return fmt.Errorf("telemetry metric %q: histograms cannot be async", name)
+		}
+		if metric.Instrument == InstrumentTypeGauge && !metric.Async {
+			return fmt.Errorf("telemetry metric %q: gauges must be async", name)
+		}
+	}
+	return nil
+}
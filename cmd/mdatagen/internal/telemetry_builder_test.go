@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateTelemetry renders the TelemetryBuilder template against the
+// samplereceiver fixture's `telemetry:` section and `go build`s the result,
+// so a template change that produces code which doesn't compile (e.g. a
+// field type that doesn't match the value assigned to it) fails this test
+// instead of only surfacing when a real component adopts `telemetry:`.
+func TestGenerateTelemetry(t *testing.T) {
+	buf, err := os.ReadFile(filepath.Join("samplereceiver", "metadata.yaml"))
+	require.NoError(t, err)
+
+	md, err := ReadMetadata(buf)
+	require.NoError(t, err)
+	require.NotEmpty(t, md.Telemetry.Metrics, "fixture must exercise the telemetry codegen path")
+
+	outputDir := filepath.Join("samplereceiver", "internal", "metadata")
+	require.NoError(t, GenerateTelemetry(md, outputDir))
+
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	cmd := exec.Command("go", "build", "./...")
+	cmd.Dir = outputDir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "generated telemetry builder does not compile:\n%s", out)
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelemetryValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tel     Telemetry
+		wantErr string
+	}{
+		{
+			name: "valid counter",
+			tel: Telemetry{
+				Metrics: map[MetricName]TelemetryMetric{
+					"batch_send_size": {Instrument: InstrumentTypeCounter},
+				},
+			},
+		},
+		{
+			name: "unsupported instrument",
+			tel: Telemetry{
+				Metrics: map[MetricName]TelemetryMetric{
+					"bad": {Instrument: "bogus"},
+				},
+			},
+			wantErr: "unsupported instrument type",
+		},
+		{
+			name: "async histogram rejected",
+			tel: Telemetry{
+				Metrics: map[MetricName]TelemetryMetric{
+					"bad": {Instrument: InstrumentTypeHistogram, Async: true},
+				},
+			},
+			wantErr: "histograms cannot be async",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tel.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package file
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"os"
@@ -22,6 +24,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -29,14 +32,28 @@ import (
 )
 
 type fileInputBenchmark struct {
-	name   string
+	name string
+	// paths are joined with rootDir. Files with ".gz"/".zst" extensions are
+	// populated via the matching compressed writer instead of plain text.
 	paths  []string
 	config func() *Config
 }
 
+func fileForPath(b *testing.B, file *os.File, path string) *benchFile {
+	switch filepath.Ext(path) {
+	case ".gz":
+		return gzipTextFile(b, file)
+	case ".zst":
+		return zstdTextFile(b, file)
+	default:
+		return simpleTextFile(b, file)
+	}
+}
+
 type benchFile struct {
 	*os.File
-	log func(int)
+	log      func(int)
+	finalize func(b *testing.B)
 }
 
 func simpleTextFile(b *testing.B, file *os.File) *benchFile {
@@ -50,6 +67,45 @@ func simpleTextFile(b *testing.B, file *os.File) *benchFile {
 	}
 }
 
+// gzipTextFile buffers plain text lines and, once finalize runs, compresses
+// the accumulated content and writes it to file. This mirrors how logrotate
+// produces a rotated-then-compressed file in one shot.
+func gzipTextFile(b *testing.B, file *os.File) *benchFile {
+	line := stringWithLength(49) + "\n"
+	var buf bytes.Buffer
+	return &benchFile{
+		File: file,
+		log: func(_ int) {
+			buf.WriteString(line)
+		},
+		finalize: func(b *testing.B) {
+			gw := gzip.NewWriter(file)
+			_, err := gw.Write(buf.Bytes())
+			require.NoError(b, err)
+			require.NoError(b, gw.Close())
+		},
+	}
+}
+
+// zstdTextFile is the zstd equivalent of gzipTextFile.
+func zstdTextFile(b *testing.B, file *os.File) *benchFile {
+	line := stringWithLength(49) + "\n"
+	var buf bytes.Buffer
+	return &benchFile{
+		File: file,
+		log: func(_ int) {
+			buf.WriteString(line)
+		},
+		finalize: func(b *testing.B) {
+			zw, err := zstd.NewWriter(file)
+			require.NoError(b, err)
+			_, err = zw.Write(buf.Bytes())
+			require.NoError(b, err)
+			require.NoError(b, zw.Close())
+		},
+	}
+}
+
 func BenchmarkFileInput(b *testing.B) {
 	cases := []fileInputBenchmark{
 		{
@@ -141,6 +197,52 @@ func BenchmarkFileInput(b *testing.B) {
 				return cfg
 			},
 		},
+		{
+			name: "GzipSingle",
+			paths: []string{
+				"file0.log.gz",
+			},
+			config: func() *Config {
+				cfg := NewConfig("test_id")
+				cfg.Include = []string{
+					"file*.log.gz",
+				}
+				cfg.Compression = "gzip"
+				return cfg
+			},
+		},
+		{
+			name: "ZstdGlob",
+			paths: []string{
+				"file0.log.zst",
+				"file1.log.zst",
+				"file2.log.zst",
+				"file3.log.zst",
+			},
+			config: func() *Config {
+				cfg := NewConfig("test_id")
+				cfg.Include = []string{"file*.log.zst"}
+				cfg.Compression = "zstd"
+				return cfg
+			},
+		},
+		{
+			// RotatedCompressed simulates logrotate's app.log -> app.log.1.gz
+			// pattern: the active file is plain text, its rotated
+			// predecessor is gzip-compressed, and both must be tailed as
+			// distinct files sharing the same reader pool.
+			name: "RotatedCompressed",
+			paths: []string{
+				"app.log",
+				"app.log.1.gz",
+			},
+			config: func() *Config {
+				cfg := NewConfig("test_id")
+				cfg.Include = []string{"app.log*"}
+				cfg.Compression = "auto"
+				return cfg
+			},
+		},
 	}
 
 	for _, bench := range cases {
@@ -150,7 +252,7 @@ func BenchmarkFileInput(b *testing.B) {
 			var files []*benchFile
 			for _, path := range bench.paths {
 				file := openFile(b, filepath.Join(rootDir, path))
-				files = append(files, simpleTextFile(b, file))
+				files = append(files, fileForPath(b, file, path))
 			}
 
 			cfg := bench.config()
@@ -174,6 +276,11 @@ func BenchmarkFileInput(b *testing.B) {
 					file.log(i)
 				}
 			}
+			for _, file := range files {
+				if file.finalize != nil {
+					file.finalize(b)
+				}
+			}
 
 			b.ResetTimer()
 			err = op.Start(testutil.NewMockPersister("test"))
@@ -186,6 +293,43 @@ func BenchmarkFileInput(b *testing.B) {
 	}
 }
 
+// BenchmarkWatch measures new-file discovery latency under fsnotify mode by
+// creating files after op.Start, rather than pre-populating them beforehand
+// as BenchmarkFileInput does.
+func BenchmarkWatch(b *testing.B) {
+	rootDir := b.TempDir()
+
+	cfg := NewConfig("test_id")
+	cfg.Include = []string{filepath.Join(rootDir, "file*.log")}
+	cfg.OutputIDs = []string{"fake"}
+	cfg.StartAt = "beginning"
+	cfg.Watcher = "fsnotify"
+
+	op, err := cfg.Build(testutil.Logger(b))
+	require.NoError(b, err)
+
+	fakeOutput := testutil.NewFakeOutput(b)
+	go func() {
+		for range fakeOutput.Received {
+		}
+	}()
+	err = op.SetOutputs([]operator.Operator{fakeOutput})
+	require.NoError(b, err)
+
+	err = op.Start(testutil.NewMockPersister("test"))
+	require.NoError(b, err)
+	defer func() {
+		require.NoError(b, op.Stop())
+	}()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		file := openFile(b, filepath.Join(rootDir, fmt.Sprintf("file%d.log", i)))
+		_, err := file.WriteString(stringWithLength(49) + "\n")
+		require.NoError(b, err)
+	}
+}
+
 func BenchmarkPoll(b *testing.B) {
 	fileCount := 20
 	rootDir := b.TempDir()
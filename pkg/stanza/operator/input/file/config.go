@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "file_input"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig("") })
+}
+
+// NewConfig creates a new Config with default values.
+func NewConfig(operatorID string) *Config {
+	return &Config{
+		InputConfig:        helper.NewInputConfig(operatorID, operatorType),
+		PollInterval:       200 * time.Millisecond,
+		FingerprintSize:    defaultFingerprintSize,
+		MaxLogSize:         1024 * 1024,
+		MaxConcurrentFiles: 1024,
+		StartAt:            "end",
+		Compression:        defaultCompression,
+		Watcher:            watcherPoll,
+		ReconcileInterval:  1 * time.Minute,
+	}
+}
+
+// Config is the configuration of a file input operator.
+type Config struct {
+	helper.InputConfig `mapstructure:",squash"`
+
+	Include []string `mapstructure:"include,omitempty"`
+	Exclude []string `mapstructure:"exclude,omitempty"`
+
+	PollInterval       time.Duration `mapstructure:"poll_interval,omitempty"`
+	MaxConcurrentFiles int           `mapstructure:"max_concurrent_files,omitempty"`
+	FingerprintSize    int           `mapstructure:"fingerprint_size,omitempty"`
+	MaxLogSize         int           `mapstructure:"max_log_size,omitempty"`
+	StartAt            string        `mapstructure:"start_at,omitempty"`
+
+	// Compression identifies how on-disk files are compressed, so that the
+	// reader can transparently decompress before fingerprinting and line
+	// splitting. One of "auto" (detect from extension), "none", "gzip",
+	// "zstd" or "bzip2". Defaults to "auto".
+	Compression string `mapstructure:"compression,omitempty"`
+
+	// Watcher selects how new and changed files are discovered. One of
+	// "poll" (the original PollInterval-driven scan), "fsnotify" (subscribe
+	// to filesystem create/rename/write events via inotify/kqueue) or
+	// "hybrid" (fsnotify for new-file discovery, plus a much longer
+	// reconciliation poll to catch anything fsnotify missed). Defaults to
+	// "poll". Filesystems without inotify/kqueue support (NFS, FUSE, Windows
+	// network shares) fall back to polling regardless of this setting.
+	Watcher string `mapstructure:"watcher,omitempty"`
+
+	// ReconcileInterval is the poll interval used by "hybrid" watcher mode
+	// to catch any fsnotify events that were missed.
+	ReconcileInterval time.Duration `mapstructure:"reconcile_interval,omitempty"`
+}
+
+// Build will build a file input operator from the supplied configuration.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	inputOperator, err := c.InputConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.Include) == 0 {
+		return nil, fmt.Errorf("required argument `include` is empty")
+	}
+
+	if c.FingerprintSize < minFingerprintSize {
+		return nil, fmt.Errorf("`fingerprint_size` must be at least %d bytes", minFingerprintSize)
+	}
+
+	if c.MaxConcurrentFiles <= 1 {
+		return nil, fmt.Errorf("`max_concurrent_files` must be a positive number more than 1")
+	}
+
+	decompressor, err := newDecompressor(c.Compression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid `compression`: %w", err)
+	}
+
+	switch c.Watcher {
+	case watcherPoll, watcherFSNotify, watcherHybrid, "":
+	default:
+		return nil, fmt.Errorf("invalid `watcher` %q, must be one of poll, fsnotify, hybrid", c.Watcher)
+	}
+
+	return &Input{
+		InputOperator:      inputOperator,
+		include:            c.Include,
+		exclude:            c.Exclude,
+		pollInterval:       c.PollInterval,
+		maxConcurrentFiles: c.MaxConcurrentFiles,
+		fingerprintSize:    c.FingerprintSize,
+		maxLogSize:         c.MaxLogSize,
+		startAtBeginning:   c.StartAt == "beginning",
+		decompressor:       decompressor,
+		watcherMode:        c.Watcher,
+		reconcileInterval:  c.ReconcileInterval,
+	}, nil
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	defaultCompression = "auto"
+	compressionNone    = "none"
+	compressionGzip    = "gzip"
+	compressionZstd    = "zstd"
+	compressionBzip2   = "bzip2"
+)
+
+// decompressor wraps a *os.File in a streaming decompressor, if the file's
+// on-disk representation is compressed. Fingerprinting and line splitting
+// always operate on the decompressed byte stream, so a rotated file that
+// logrotate has since compressed (e.g. app.log -> app.log.1.gz) is still
+// recognized as a continuation of the same underlying file.
+type decompressor struct {
+	kind string
+}
+
+func newDecompressor(kind string) (*decompressor, error) {
+	switch kind {
+	case "", defaultCompression, compressionNone, compressionGzip, compressionZstd, compressionBzip2:
+		return &decompressor{kind: kind}, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q, must be one of auto, none, gzip, zstd, bzip2", kind)
+	}
+}
+
+// kindForPath resolves "auto" against a file's extension. Files with no
+// recognized compressed extension are treated as uncompressed.
+func (d *decompressor) kindForPath(path string) string {
+	if d.kind != defaultCompression && d.kind != "" {
+		return d.kind
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gz":
+		return compressionGzip
+	case ".zst":
+		return compressionZstd
+	case ".bz2":
+		return compressionBzip2
+	default:
+		return compressionNone
+	}
+}
+
+// wrap returns a reader over the decompressed contents of r, which was read
+// from the file at path. For uncompressed files, r is returned unchanged.
+//
+// The returned reader is NOT seekable: resuming mid-archive after a restart
+// works by re-decompressing from the start of the compressed file and
+// discarding bytes up to the persisted decompressed offset, since gzip/zstd/
+// bzip2 streams cannot seek directly to an arbitrary decompressed offset.
+func (d *decompressor) wrap(path string, r io.Reader) (io.Reader, error) {
+	switch d.kindForPath(path) {
+	case compressionGzip:
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("open gzip stream: %w", err)
+		}
+		return gr, nil
+	case compressionZstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("open zstd stream: %w", err)
+		}
+		return zr.IOReadCloser(), nil
+	case compressionBzip2:
+		return bzip2.NewReader(r), nil
+	default:
+		return r, nil
+	}
+}
+
+// seekable reports whether files of this kind support seeking directly to a
+// persisted byte offset, or must instead be re-decompressed from the start
+// and have the leading bytes discarded.
+func (d *decompressor) seekable(path string) bool {
+	return d.kindForPath(path) == compressionNone
+}
@@ -0,0 +1,383 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// persisterKey is the single key under which Input checkpoints the state of
+// every file it is tailing.
+const persisterKey = "file_input_known_files"
+
+// Input tails one or more files matched by a glob, emitting each complete
+// line as an entry. See decompressor.go for transparent handling of
+// compressed and rotated-then-compressed files.
+type Input struct {
+	helper.InputOperator
+
+	include []string
+	exclude []string
+
+	pollInterval       time.Duration
+	maxConcurrentFiles int
+	fingerprintSize    int
+	maxLogSize         int
+	startAtBeginning   bool
+
+	decompressor *decompressor
+
+	watcherMode       string
+	reconcileInterval time.Duration
+	watcher           *fileWatcher
+
+	persister      operator.Persister
+	persistedState map[string]persistedState
+	bufferPool     *sync.Pool
+
+	// readers are the files matched as of the most recent poll, identified
+	// by Fingerprint rather than path so a rename or rotation is recognized
+	// as a continuation instead of a new file. lost holds readers whose
+	// path disappeared from the glob on the last poll; they are kept for
+	// exactly one further poll so any bytes still sitting behind an fd that
+	// outlived a rename are flushed before the reader is closed.
+	readers []*Reader
+	lost    []*Reader
+	mu      sync.Mutex
+
+	// pollMu serializes poll itself. In "hybrid" mode, fsnotify events and
+	// the reconcile ticker each drive poll from their own goroutine; without
+	// this, two concurrent polls could each resolve disjoint reader sets for
+	// the same path and race on which one wins i.readers, leaking the fd and
+	// reader the losing poll resolved.
+	pollMu sync.Mutex
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// Start begins discovering matched files for new content, per the
+// configured Watcher mode.
+func (i *Input) Start(persister operator.Persister) error {
+	i.persister = persister
+	i.persistedState = i.loadPersistedState(context.Background())
+	i.bufferPool = &sync.Pool{
+		New: func() interface{} {
+			return make([]byte, i.fingerprintSize)
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cancel = cancel
+
+	switch i.watcherMode {
+	case watcherFSNotify, watcherHybrid:
+		w, err := newFileWatcher(i)
+		if err != nil {
+			// Fall back to polling on filesystems without inotify/kqueue
+			// support (NFS, FUSE, Windows network shares).
+			i.Warnf("fsnotify unavailable, falling back to polling: %s", err)
+			i.poll(ctx)
+			i.startPolling(ctx, i.pollInterval)
+			return nil
+		}
+		i.watcher = w
+		// Tail whatever already matches on startup (e.g. after a collector
+		// restart with pre-existing logs); events only cover what changes
+		// from here on.
+		i.poll(ctx)
+		i.wg.Add(1)
+		go func() {
+			defer i.wg.Done()
+			w.run(ctx)
+		}()
+		if i.watcherMode == watcherHybrid {
+			i.startPolling(ctx, i.reconcileInterval)
+		}
+	default:
+		i.poll(ctx)
+		i.startPolling(ctx, i.pollInterval)
+	}
+	return nil
+}
+
+// startPolling launches the timer-driven poll loop used by "poll" mode, and
+// as the reconciliation pass in "hybrid" mode. The first pass already ran
+// synchronously in Start, so this only schedules subsequent ones.
+func (i *Input) startPolling(ctx context.Context, interval time.Duration) {
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				i.poll(ctx)
+			}
+		}
+	}()
+}
+
+// Stop halts discovery, checkpoints every tracked file's state, and
+// releases its resources.
+func (i *Input) Stop() error {
+	if i.cancel != nil {
+		i.cancel()
+	}
+	i.wg.Wait()
+	if i.watcher != nil {
+		i.watcher.close()
+	}
+
+	i.savePersistedState(context.Background())
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, r := range i.readers {
+		r.Close()
+	}
+	for _, r := range i.lost {
+		r.Close()
+	}
+	i.readers = nil
+	i.lost = nil
+	return nil
+}
+
+// poll finds files matching the configured glob(s), reconciles them by
+// fingerprint against the files tracked since the last poll, and reads any
+// new content from each, up to maxConcurrentFiles at a time.
+func (i *Input) poll(ctx context.Context) {
+	i.pollMu.Lock()
+	defer i.pollMu.Unlock()
+
+	paths := i.matchedPaths()
+
+	used := make(map[*Reader]bool)
+	var current []*Reader
+	for _, path := range paths {
+		r, err := i.resolveReader(path, used)
+		if err != nil {
+			i.Errorf("open file %q: %s", path, err)
+			continue
+		}
+		used[r] = true
+		current = append(current, r)
+	}
+
+	i.mu.Lock()
+	var newlyLost []*Reader
+	for _, r := range i.readers {
+		if !used[r] {
+			newlyLost = append(newlyLost, r)
+		}
+	}
+	// A lost reader that resolveReader reclaimed this poll is in current
+	// again, so it is not drained and closed.
+	var draining []*Reader
+	for _, r := range i.lost {
+		if !used[r] {
+			draining = append(draining, r)
+		}
+	}
+	i.lost = newlyLost
+	i.readers = current
+	i.mu.Unlock()
+
+	sem := make(chan struct{}, i.maxConcurrentFiles)
+	var wg sync.WaitGroup
+	readAll := func(readers []*Reader) {
+		for _, r := range readers {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(r *Reader) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				r.ReadToEnd(ctx)
+			}(r)
+		}
+	}
+	readAll(current)
+	readAll(draining)
+	wg.Wait()
+
+	for _, r := range draining {
+		r.Close()
+	}
+}
+
+// matchedPaths expands the configured include/exclude globs.
+func (i *Input) matchedPaths() []string {
+	includeSet := map[string]struct{}{}
+	var matches []string
+	for _, include := range i.include {
+		paths, err := filepath.Glob(include)
+		if err != nil {
+			i.Errorf("glob pattern %q: %s", include, err)
+			continue
+		}
+		for _, path := range paths {
+			if _, ok := includeSet[path]; ok {
+				continue
+			}
+			includeSet[path] = struct{}{}
+			matches = append(matches, path)
+		}
+	}
+
+	for _, exclude := range i.exclude {
+		excludePaths, err := filepath.Glob(exclude)
+		if err != nil {
+			continue
+		}
+		excludeSet := map[string]struct{}{}
+		for _, path := range excludePaths {
+			excludeSet[path] = struct{}{}
+		}
+		var filtered []string
+		for _, path := range matches {
+			if _, ok := excludeSet[path]; !ok {
+				filtered = append(filtered, path)
+			}
+		}
+		matches = filtered
+	}
+	return matches
+}
+
+// resolveReader returns the Reader that should track path this poll: the
+// reader already at that path, a reader relocated here by a rename (same
+// Fingerprint, different path), or a freshly created one. used records
+// readers already claimed earlier in the same poll so two matched paths
+// that momentarily share a fingerprint (e.g. two empty files) don't collide.
+func (i *Input) resolveReader(path string, used map[*Reader]bool) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fp, err := i.readFingerprint(file, path)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	i.mu.Lock()
+	candidates := make([]*Reader, 0, len(i.readers)+len(i.lost))
+	candidates = append(candidates, i.readers...)
+	candidates = append(candidates, i.lost...)
+	i.mu.Unlock()
+
+	for _, r := range candidates {
+		if used[r] {
+			continue
+		}
+		if r.path == path || r.identifies(fp) {
+			if r.path != path {
+				r.relocate(path, file)
+			} else {
+				// Already reading from this fd; no need to reopen it every
+				// poll.
+				_ = file.Close()
+			}
+			r.Fingerprint = fp
+			return r, nil
+		}
+	}
+
+	r, err := i.newReader(path, file, fp)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if persisted, ok := i.persistedState[path]; ok {
+		r.Offset = persisted.Offset
+		r.decompressedOffset = persisted.DecompressedOffset
+	} else if !i.startAtBeginning {
+		if info, statErr := file.Stat(); statErr == nil {
+			r.Offset = info.Size()
+			r.decompressedOffset = r.Offset
+		}
+	}
+	return r, nil
+}
+
+// readFingerprint reads up to fingerprintSize bytes from the start of
+// path's decompressed byte stream, without disturbing the offset ReadToEnd
+// will itself seek to.
+func (i *Input) readFingerprint(file *os.File, path string) (*Fingerprint, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	stream, err := i.decompressor.wrap(path, file)
+	if err != nil {
+		return nil, err
+	}
+	if closer, ok := stream.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	buf := make([]byte, i.fingerprintSize)
+	n, err := io.ReadFull(stream, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	return New(buf[:n]), nil
+}
+
+// loadPersistedState restores the per-file checkpoint saved by
+// savePersistedState, returning an empty map if there is nothing persisted
+// yet (e.g. first run).
+func (i *Input) loadPersistedState(ctx context.Context) map[string]persistedState {
+	state := map[string]persistedState{}
+	if i.persister == nil {
+		return state
+	}
+	data, err := i.persister.Get(ctx, persisterKey)
+	if err != nil || len(data) == 0 {
+		return state
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		i.Errorf("unmarshal persisted file input state: %s", err)
+		return map[string]persistedState{}
+	}
+	return state
+}
+
+// savePersistedState checkpoints every currently tracked file's offset and
+// fingerprint so tailing (including mid-archive, for compressed files) can
+// resume after a restart.
+func (i *Input) savePersistedState(ctx context.Context) {
+	if i.persister == nil {
+		return
+	}
+
+	i.mu.Lock()
+	state := make(map[string]persistedState, len(i.readers))
+	for _, r := range i.readers {
+		state[r.path] = r.toPersisted()
+	}
+	i.mu.Unlock()
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		i.Errorf("marshal file input state: %s", err)
+		return
+	}
+	if err := i.persister.Set(ctx, persisterKey, data); err != nil {
+		i.Errorf("persist file input state: %s", err)
+	}
+}
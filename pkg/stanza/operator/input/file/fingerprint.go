@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
+
+import "bytes"
+
+// defaultFingerprintSize is the number of bytes, from the start of the
+// decompressed byte stream, used to fingerprint a file.
+const defaultFingerprintSize = 1000 // bytes
+
+// minFingerprintSize is the minimum number of bytes a fingerprint may be
+// configured with.
+const minFingerprintSize = 16 // bytes
+
+// Fingerprint is used to identify a file by a prefix of bytes read from the
+// start of its (decompressed) content. Two files whose fingerprints share a
+// prefix relationship are considered the same underlying file, which allows
+// a rotated-then-renamed (or rotated-then-compressed) file to be recognized
+// as a continuation of the file it replaced.
+type Fingerprint struct {
+	FirstBytes []byte
+}
+
+// New creates a new Fingerprint from the given bytes.
+func New(first []byte) *Fingerprint {
+	fp := &Fingerprint{FirstBytes: make([]byte, len(first))}
+	copy(fp.FirstBytes, first)
+	return fp
+}
+
+// Copy creates a deep copy of the Fingerprint.
+func (f *Fingerprint) Copy() *Fingerprint {
+	if f == nil {
+		return nil
+	}
+	return New(f.FirstBytes)
+}
+
+// Equal returns true if the fingerprints are the same.
+func (f *Fingerprint) Equal(other *Fingerprint) bool {
+	if f == nil || other == nil {
+		return f == other
+	}
+	return bytes.Equal(f.FirstBytes, other.FirstBytes)
+}
+
+// StartsWith returns true if this fingerprint has the given fingerprint as a
+// prefix, meaning this fingerprint likely belongs to the same file, having
+// grown since the given fingerprint was recorded.
+func (f *Fingerprint) StartsWith(other *Fingerprint) bool {
+	if f == nil || other == nil || len(other.FirstBytes) == 0 {
+		return false
+	}
+	if len(f.FirstBytes) < len(other.FirstBytes) {
+		return false
+	}
+	return bytes.Equal(f.FirstBytes[:len(other.FirstBytes)], other.FirstBytes)
+}
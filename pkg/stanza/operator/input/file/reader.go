@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Reader tracks the state needed to continue tailing a single file across
+// polls: its identity (Fingerprint), how far into the decompressed byte
+// stream it has been read, and the splitter used to find line boundaries.
+type Reader struct {
+	*Input
+
+	file *os.File
+	path string
+
+	Fingerprint *Fingerprint
+	Offset      int64
+
+	decompressedOffset int64
+}
+
+// newReader creates a new, unstarted Reader for the file at path.
+func (i *Input) newReader(path string, file *os.File, fp *Fingerprint) (*Reader, error) {
+	return &Reader{
+		Input:       i,
+		file:        file,
+		path:        path,
+		Fingerprint: fp,
+	}, nil
+}
+
+// ReadToEnd reads whatever new, complete lines are available in the file,
+// transparently decompressing it first if needed, and emits them to the
+// input's configured outputs.
+func (r *Reader) ReadToEnd(ctx context.Context) {
+	var stream io.Reader = r.file
+	if !r.decompressor.seekable(r.path) {
+		if _, err := r.file.Seek(0, io.SeekStart); err != nil {
+			r.Errorf("seek to start of compressed file %q: %s", r.path, err)
+			return
+		}
+	} else if _, err := r.file.Seek(r.Offset, io.SeekStart); err != nil {
+		r.Errorf("seek to offset in file %q: %s", r.path, err)
+		return
+	}
+
+	decompressed, err := r.decompressor.wrap(r.path, stream)
+	if err != nil {
+		r.Errorf("decompress file %q: %s", r.path, err)
+		return
+	}
+
+	// For non-seekable (compressed) streams, resume by discarding bytes up
+	// to the previously persisted decompressed offset.
+	if !r.decompressor.seekable(r.path) && r.decompressedOffset > 0 {
+		if _, err := io.CopyN(io.Discard, decompressed, r.decompressedOffset); err != nil && err != io.EOF {
+			r.Errorf("resume decompressed offset in file %q: %s", r.path, err)
+			return
+		}
+	}
+
+	buf := r.bufferPool.Get().([]byte)
+	defer r.bufferPool.Put(buf) //nolint:staticcheck
+
+	var advance int64
+	scanner := bufio.NewScanner(decompressed)
+	scanner.Buffer(buf, r.maxLogSize)
+	scanner.Split(newlineSplitFunc(&advance))
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		line := scanner.Bytes()
+		r.decompressedOffset += advance
+		if r.decompressor.seekable(r.path) {
+			r.Offset = r.decompressedOffset
+		}
+		r.emit(ctx, line)
+	}
+	if err := scanner.Err(); err != nil {
+		r.Errorf("scan file %q: %s", r.path, err)
+	}
+}
+
+// newlineSplitFunc returns a bufio.SplitFunc identical to bufio.ScanLines,
+// except that it never returns a final, unterminated line as a token. A
+// file being actively tailed almost always has a partial line sitting at
+// its end; ScanLines would hand that back as a complete entry on EOF and
+// the offset tracking would then count a newline that was never actually
+// there, permanently corrupting the resume point. Holding the partial line
+// back means it is picked up, now complete, on the next poll. The number of
+// bytes consumed for the most recently returned token is written to
+// *consumed so the caller can advance its own byte offset precisely.
+func newlineSplitFunc(consumed *int64) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			// No newline yet: request more data if there is any to come;
+			// otherwise stop without emitting the trailing partial line.
+			return 0, nil, nil
+		}
+		token = data[:i]
+		if len(token) > 0 && token[len(token)-1] == '\r' {
+			token = token[:len(token)-1]
+		}
+		*consumed = int64(i + 1)
+		return i + 1, token, nil
+	}
+}
+
+func (r *Reader) emit(ctx context.Context, line []byte) {
+	ent, err := r.NewEntry(string(line))
+	if err != nil {
+		r.Errorf("create entry: %s", err)
+		return
+	}
+	r.Write(ctx, ent)
+}
+
+// identifies reports whether fp belongs to the same underlying file this
+// Reader is already tracking: either fingerprint is a byte-for-byte prefix
+// of the other, since a growing file's fingerprint only ever gains bytes.
+func (r *Reader) identifies(fp *Fingerprint) bool {
+	return r.Fingerprint.Equal(fp) || r.Fingerprint.StartsWith(fp) || fp.StartsWith(r.Fingerprint)
+}
+
+// relocate adopts a newly opened handle for the same underlying file found
+// at a new path (a rename), closing the one it held before.
+func (r *Reader) relocate(path string, file *os.File) {
+	old := r.file
+	r.file = file
+	r.path = path
+	if old != nil {
+		_ = old.Close()
+	}
+}
+
+func (r *Reader) Close() {
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			r.Errorf("closing file: %s", err)
+		}
+	}
+}
+
+var _ fmt.Stringer = (*Reader)(nil)
+
+func (r *Reader) String() string {
+	return r.path
+}
+
+// persistedState is the subset of Reader state checkpointed between polls so
+// tailing can resume after a restart.
+type persistedState struct {
+	Offset             int64
+	DecompressedOffset int64
+	Fingerprint        []byte
+}
+
+func (r *Reader) toPersisted() persistedState {
+	return persistedState{
+		Offset:             r.Offset,
+		DecompressedOffset: r.decompressedOffset,
+		Fingerprint:        r.Fingerprint.FirstBytes,
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewlineSplitFuncHoldsBackUnterminatedLine(t *testing.T) {
+	var advance int64
+	scanner := bufio.NewScanner(strings.NewReader("complete line\r\npartial line with no newline"))
+	scanner.Split(newlineSplitFunc(&advance))
+
+	require.True(t, scanner.Scan())
+	require.Equal(t, "complete line", scanner.Text())
+	require.Equal(t, int64(len("complete line\r\n")), advance)
+
+	// The trailing, unterminated line must not be returned as a token: a
+	// file being actively tailed almost always ends mid-line, and treating
+	// that as a complete entry would also overcount the persisted offset by
+	// a newline that was never there.
+	require.False(t, scanner.Scan())
+	require.NoError(t, scanner.Err())
+}
+
+func TestNewlineSplitFuncReturnsCompletedLineOnNextScan(t *testing.T) {
+	var advance int64
+	scanner := bufio.NewScanner(strings.NewReader("line one\nline two\n"))
+	scanner.Split(newlineSplitFunc(&advance))
+
+	require.True(t, scanner.Scan())
+	require.Equal(t, "line one", scanner.Text())
+	require.Equal(t, int64(len("line one\n")), advance)
+
+	require.True(t, scanner.Scan())
+	require.Equal(t, "line two", scanner.Text())
+	require.Equal(t, int64(len("line two\n")), advance)
+
+	require.False(t, scanner.Scan())
+}
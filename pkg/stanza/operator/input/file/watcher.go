@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	watcherPoll     = "poll"
+	watcherFSNotify = "fsnotify"
+	watcherHybrid   = "hybrid"
+)
+
+// fileWatcher subscribes to filesystem create/rename/write events for the
+// directories containing the configured Include globs, and runs a poll of
+// all matched files whenever a relevant file changes. It feeds into the same
+// reader pool poll uses, so MaxConcurrentFiles is honored identically
+// whether a file was discovered by fsnotify or by a poll.
+//
+// A new file can fire a Create event before it has grown to FingerprintSize;
+// readFingerprint tolerates a short read and ReadToEnd simply finds nothing
+// new until a later Write event arrives, so fingerprint-based identity is
+// unaffected.
+type fileWatcher struct {
+	input   *Input
+	watcher *fsnotify.Watcher
+}
+
+func newFileWatcher(i *Input) (*fileWatcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := watchedDirs(i.include)
+	for _, dir := range dirs {
+		if err := w.Add(dir); err != nil {
+			_ = w.Close()
+			return nil, err
+		}
+	}
+
+	return &fileWatcher{input: i, watcher: w}, nil
+}
+
+// watchedDirs returns the deduplicated set of directories containing the
+// given glob patterns, since fsnotify watches directories, not globs.
+func watchedDirs(includes []string) []string {
+	seen := map[string]struct{}{}
+	var dirs []string
+	for _, include := range includes {
+		dir := filepath.Dir(include)
+		if _, ok := seen[dir]; ok {
+			continue
+		}
+		seen[dir] = struct{}{}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// run consumes filesystem events until ctx is canceled, triggering a
+// (cheap) poll restricted to the matched globs whenever a relevant file is
+// created, written to, or renamed into place.
+func (fw *fileWatcher) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-fw.watcher.Events:
+			if !ok {
+				return
+			}
+			if !fw.relevant(event) {
+				continue
+			}
+			fw.input.poll(ctx)
+		case err, ok := <-fw.watcher.Errors:
+			if !ok {
+				return
+			}
+			fw.input.Errorf("fsnotify watch error: %s", err)
+		}
+	}
+}
+
+// relevant reports whether event.Name matches one of the configured
+// Include globs and none of the Exclude globs.
+func (fw *fileWatcher) relevant(event fsnotify.Event) bool {
+	if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Rename) == 0 {
+		return false
+	}
+	for _, exclude := range fw.input.exclude {
+		if ok, _ := filepath.Match(exclude, event.Name); ok {
+			return false
+		}
+	}
+	for _, include := range fw.input.include {
+		if ok, _ := filepath.Match(include, event.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (fw *fileWatcher) close() {
+	_ = fw.watcher.Close()
+}